@@ -5,12 +5,42 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 var registeredFlags = make(map[string]bool)
 
+// Supported exporter transport protocols. These mirror the values accepted
+// by the OTel SDK's OTLP exporters.
+//
+// An "arrow" protocol, backed by an OTel-Arrow columnar gRPC stream and a
+// standard-OTLP fallback on Unimplemented, was attempted but descoped: it
+// shipped without the generated Arrow service client wired in, so every
+// send was a silent no-op. Re-adding it needs a real
+// github.com/open-telemetry/otel-arrow-backed stream implementation, not
+// just the two protocols below.
+const (
+	ProtocolGRPC         = "grpc"
+	ProtocolHTTPProtobuf = "http/protobuf"
+)
+
+// Supported exporter destinations, selected independently of the transport
+// protocol above.
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// Supported OTLP wire compression modes.
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+)
+
 // registeredFlag registers command line arguments and tracks them to avoid reregistration.
 func registerFlag(name string, value *string, usage string) error {
 	defaultValue := os.Getenv(name)
@@ -27,6 +57,59 @@ func registerFlag(name string, value *string, usage string) error {
 	return nil
 }
 
+// registerUintFlag registers a uint64 command line argument and tracks it to
+// avoid reregistration, mirroring registerFlag's string handling.
+func registerUintFlag(name string, value *uint64, usage string) error {
+	var defaultValue uint64
+
+	if raw := os.Getenv(name); raw != "" {
+		parsed, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", name, err)
+		}
+		defaultValue = parsed
+	}
+
+	if !registeredFlags[name] {
+		flag.Uint64Var(value, name, defaultValue, usage)
+		registeredFlags[name] = true
+	}
+
+	if registeredFlags[name] && defaultValue != 0 {
+		*value = defaultValue
+	}
+
+	return nil
+}
+
+// registerBoolFlag registers a bool command line argument and tracks it to
+// avoid reregistration, mirroring registerFlag's string handling.
+// defaultIfUnset is used when the environment variable isn't set.
+func registerBoolFlag(name string, value *bool, defaultIfUnset bool, usage string) error {
+	defaultValue := defaultIfUnset
+	envSet := false
+
+	if raw := os.Getenv(name); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", name, err)
+		}
+		defaultValue = parsed
+		envSet = true
+	}
+
+	if !registeredFlags[name] {
+		flag.BoolVar(value, name, defaultValue, usage)
+		registeredFlags[name] = true
+	}
+
+	if registeredFlags[name] && envSet {
+		*value = defaultValue
+	}
+
+	return nil
+}
+
 // Config is the configuration struct for the service.
 type Config struct {
 	CollectorGRPCURL string
@@ -36,17 +119,157 @@ type Config struct {
 	MetricsPort      uint64
 	TracerPort       uint64
 	LoggerPort       uint64
+
+	// TracesProtocol, MetricsProtocol and LogsProtocol select the exporter
+	// transport used for each signal independently. Each accepts "grpc" or
+	// "http/protobuf". Empty falls back to the signal's historical default
+	// (grpc for traces, http/protobuf for metrics and logs).
+	TracesProtocol  string
+	MetricsProtocol string
+	LogsProtocol    string
+
+	// Workloads is a comma-separated list of workload names to run
+	// concurrently (e.g. "dice,http,sql,errors"). Empty defaults to "dice".
+	Workloads string
+	// WorkloadRate caps each workload worker to roughly this many operations
+	// per second. Zero means unthrottled.
+	WorkloadRate uint64
+	// WorkloadConcurrency is the number of concurrent workers started per
+	// configured workload. Zero defaults to 1.
+	WorkloadConcurrency uint64
+
+	// Sampler selects the head sampler used by the trace provider: "always"
+	// (default), "never", or "ratio:<fraction>" for a ParentBased
+	// TraceIDRatio sampler.
+	Sampler string
+	// TailSampleWindowMS buffers each trace's finished spans for this many
+	// milliseconds before deciding whether to export it. Zero disables tail
+	// sampling.
+	TailSampleWindowMS uint64
+	// TailSampleLatencyThresholdMS is the trace duration, in milliseconds,
+	// at or above which a trace is kept regardless of error status. Only
+	// consulted when TailSampleWindowMS is non-zero.
+	TailSampleLatencyThresholdMS uint64
+
+	// Exporter selects the destination every signal's exporter writes to:
+	// "otlp" (default) sends to the collector endpoints above, "stdout"
+	// prints the OTel Go SDK stdout exporters' newline-delimited JSON to
+	// stdout, and "file" writes the same format to
+	// TracesFilePath/MetricsFilePath/LogsFilePath. That JSON is the SDK's
+	// own encoding of its span, metric and log records, not OTLP-JSON.
+	Exporter string
+	// TracesFilePath, MetricsFilePath and LogsFilePath are the output files
+	// used when Exporter is "file".
+	TracesFilePath  string
+	MetricsFilePath string
+	LogsFilePath    string
+	// ExporterFileMaxBytes rotates a file exporter's output before a write
+	// would take it past this size. Zero disables rotation.
+	ExporterFileMaxBytes uint64
+
+	// CollectorInsecure disables TLS on the OTLP connections. Defaults to
+	// true, preserving ogen's historical behaviour of talking to a local,
+	// unauthenticated collector.
+	CollectorInsecure bool
+	// CollectorCAFile, CollectorClientCertFile and CollectorClientKeyFile
+	// configure mTLS against the collector. CollectorCAFile alone enables
+	// server verification against a custom CA; the client cert and key
+	// files together enable a client certificate. Ignored when
+	// CollectorInsecure is true.
+	CollectorCAFile         string
+	CollectorClientCertFile string
+	CollectorClientKeyFile  string
+	// CollectorHeaders is a comma-separated list of "key=value" pairs sent
+	// as request headers/metadata on every OTLP call, for collectors that
+	// authenticate via an API key or bearer token header.
+	CollectorHeaders string
+	// CollectorCompression selects the OTLP wire compression: "gzip" or
+	// "none". Empty preserves each signal's historical default: gzip for
+	// the metrics and logs HTTP exporters, none everywhere else (grpc, and
+	// the traces HTTP exporter).
+	CollectorCompression string
+}
+
+// validateProtocol ensures value, if set, is one of the supported exporter protocols.
+func validateProtocol(field, value string) error {
+	switch value {
+	case "", ProtocolGRPC, ProtocolHTTPProtobuf:
+		return nil
+	default:
+		return fmt.Errorf("%s must be one of %q, %q, got %q", field, ProtocolGRPC, ProtocolHTTPProtobuf, value)
+	}
+}
+
+// validateSampler ensures value, if set, is "always", "never" or a
+// well-formed "ratio:<fraction>".
+func validateSampler(value string) error {
+	switch {
+	case value == "", value == "always", value == "never":
+		return nil
+	case strings.HasPrefix(value, "ratio:"):
+		if _, err := strconv.ParseFloat(strings.TrimPrefix(value, "ratio:"), 64); err != nil {
+			return fmt.Errorf("sampler ratio must be a float, got %q: %w", value, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("sampler must be \"always\", \"never\", or \"ratio:<fraction>\", got %q", value)
+	}
+}
+
+// validateExporter ensures value, if set, is one of the supported exporter
+// destinations.
+func validateExporter(value string) error {
+	switch value {
+	case "", ExporterOTLP, ExporterStdout, ExporterFile:
+		return nil
+	default:
+		return fmt.Errorf("exporter must be one of %q, %q, %q, got %q", ExporterOTLP, ExporterStdout, ExporterFile, value)
+	}
+}
+
+// validateCompression ensures value, if set, is a supported OTLP
+// compression mode.
+func validateCompression(value string) error {
+	switch value {
+	case "", CompressionNone, CompressionGzip:
+		return nil
+	default:
+		return fmt.Errorf("collector compression must be %q or %q, got %q", CompressionNone, CompressionGzip, value)
+	}
+}
+
+// validateHeaders ensures value, if set, is a comma-separated list of
+// "key=value" pairs.
+func validateHeaders(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		if !strings.Contains(pair, "=") {
+			return fmt.Errorf("collector headers must be a comma-separated list of key=value pairs, got %q", pair)
+		}
+	}
+
+	return nil
 }
 
 // validate ensures that the configuration is valid.
 func (c *Config) validate() error {
 	var errs error
 
-	if c.CollectorGRPCURL == "" {
+	// The collector endpoints are only required for the default "otlp"
+	// exporter; stdout/file destinations never dial a collector.
+	exporter := c.Exporter
+	if exporter == "" {
+		exporter = ExporterOTLP
+	}
+
+	if exporter == ExporterOTLP && c.CollectorGRPCURL == "" {
 		errs = errors.Join(errs, fmt.Errorf("collector grpc endpoint required"))
 	}
 
-	if c.CollectorHTTPURL == "" {
+	if exporter == ExporterOTLP && c.CollectorHTTPURL == "" {
 		errs = errors.Join(errs, fmt.Errorf("collector http endpoint required"))
 	}
 
@@ -58,6 +281,50 @@ func (c *Config) validate() error {
 		errs = errors.Join(errs, fmt.Errorf("pprof endpoint required"))
 	}
 
+	if err := validateProtocol("traces protocol", c.TracesProtocol); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := validateProtocol("metrics protocol", c.MetricsProtocol); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := validateProtocol("logs protocol", c.LogsProtocol); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := validateSampler(c.Sampler); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := validateExporter(c.Exporter); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if exporter == ExporterFile {
+		if c.TracesFilePath == "" {
+			errs = errors.Join(errs, fmt.Errorf("traces file path required for the file exporter"))
+		}
+		if c.MetricsFilePath == "" {
+			errs = errors.Join(errs, fmt.Errorf("metrics file path required for the file exporter"))
+		}
+		if c.LogsFilePath == "" {
+			errs = errors.Join(errs, fmt.Errorf("logs file path required for the file exporter"))
+		}
+	}
+
+	if err := validateCompression(c.CollectorCompression); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if err := validateHeaders(c.CollectorHeaders); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	if (c.CollectorClientCertFile == "") != (c.CollectorClientKeyFile == "") {
+		errs = errors.Join(errs, fmt.Errorf("collector client cert and key files must be set together"))
+	}
+
 	return errs
 }
 
@@ -81,6 +348,26 @@ func loadConfig(cfg *Config, path string) error {
 	registerFlag("collectorhttpurl", &cfg.CollectorHTTPURL, "the collector http endpoint")
 	registerFlag("servicename", &cfg.ServiceName, "the service name")
 	registerFlag("pprofurl", &cfg.PprofURL, "the pprof endpoint")
+	registerFlag("tracesprotocol", &cfg.TracesProtocol, "the traces exporter protocol (grpc|http/protobuf)")
+	registerFlag("metricsprotocol", &cfg.MetricsProtocol, "the metrics exporter protocol (grpc|http/protobuf)")
+	registerFlag("logsprotocol", &cfg.LogsProtocol, "the logs exporter protocol (grpc|http/protobuf)")
+	registerFlag("workloads", &cfg.Workloads, "comma-separated workloads to run (dice,http,sql,errors)")
+	registerUintFlag("workloadrate", &cfg.WorkloadRate, "target operations per second per workload worker (0 for unthrottled)")
+	registerUintFlag("workloadconcurrency", &cfg.WorkloadConcurrency, "number of concurrent workers started per workload")
+	registerFlag("sampler", &cfg.Sampler, "the trace sampler: always|never|ratio:<fraction>")
+	registerUintFlag("tailsamplewindowms", &cfg.TailSampleWindowMS, "buffer window, in ms, for local tail sampling (0 disables it)")
+	registerUintFlag("tailsamplelatencythresholdms", &cfg.TailSampleLatencyThresholdMS, "trace duration, in ms, at or above which a trace is kept during tail sampling")
+	registerFlag("exporter", &cfg.Exporter, "the exporter destination: otlp|stdout|file")
+	registerFlag("tracesfilepath", &cfg.TracesFilePath, "output path for traces when exporter=file")
+	registerFlag("metricsfilepath", &cfg.MetricsFilePath, "output path for metrics when exporter=file")
+	registerFlag("logsfilepath", &cfg.LogsFilePath, "output path for logs when exporter=file")
+	registerUintFlag("exporterfilemaxbytes", &cfg.ExporterFileMaxBytes, "rotate a file exporter's output before a write would take it past this many bytes (0 disables rotation)")
+	registerBoolFlag("collectorinsecure", &cfg.CollectorInsecure, true, "disable TLS on the OTLP connections")
+	registerFlag("collectorcafile", &cfg.CollectorCAFile, "CA certificate file used to verify the collector")
+	registerFlag("collectorclientcertfile", &cfg.CollectorClientCertFile, "client certificate file for mTLS against the collector")
+	registerFlag("collectorclientkeyfile", &cfg.CollectorClientKeyFile, "client private key file for mTLS against the collector")
+	registerFlag("collectorheaders", &cfg.CollectorHeaders, "comma-separated key=value headers sent on every OTLP request")
+	registerFlag("collectorcompression", &cfg.CollectorCompression, "OTLP wire compression: none|gzip")
 
 	// Parse command-line flags.
 	flag.Parse()
@@ -99,6 +99,120 @@ func TestValidateConfig(t *testing.T) {
 			},
 			hasError: true,
 		},
+		{
+			name: "valid protocols",
+			config: Config{
+				CollectorGRPCURL: "localhost:4317",
+				CollectorHTTPURL: "localhost:4318",
+				ServiceName:      "ogen",
+				PprofURL:         "localhost:1777",
+				TracesProtocol:   "http/protobuf",
+				MetricsProtocol:  "grpc",
+				LogsProtocol:     "grpc",
+			},
+			hasError: false,
+		},
+		{
+			name: "invalid traces protocol",
+			config: Config{
+				CollectorGRPCURL: "localhost:4317",
+				CollectorHTTPURL: "localhost:4318",
+				ServiceName:      "ogen",
+				PprofURL:         "localhost:1777",
+				TracesProtocol:   "carrier-pigeon",
+			},
+			hasError: true,
+		},
+		{
+			name: "valid ratio sampler",
+			config: Config{
+				CollectorGRPCURL: "localhost:4317",
+				CollectorHTTPURL: "localhost:4318",
+				ServiceName:      "ogen",
+				PprofURL:         "localhost:1777",
+				Sampler:          "ratio:0.25",
+			},
+			hasError: false,
+		},
+		{
+			name: "invalid sampler ratio",
+			config: Config{
+				CollectorGRPCURL: "localhost:4317",
+				CollectorHTTPURL: "localhost:4318",
+				ServiceName:      "ogen",
+				PprofURL:         "localhost:1777",
+				Sampler:          "ratio:not-a-float",
+			},
+			hasError: true,
+		},
+		{
+			name: "stdout exporter needs no collector endpoints",
+			config: Config{
+				ServiceName: "ogen",
+				PprofURL:    "localhost:1777",
+				Exporter:    "stdout",
+			},
+			hasError: false,
+		},
+		{
+			name: "file exporter requires file paths",
+			config: Config{
+				ServiceName: "ogen",
+				PprofURL:    "localhost:1777",
+				Exporter:    "file",
+			},
+			hasError: true,
+		},
+		{
+			name: "file exporter with paths set",
+			config: Config{
+				ServiceName:     "ogen",
+				PprofURL:        "localhost:1777",
+				Exporter:        "file",
+				TracesFilePath:  "traces.jsonl",
+				MetricsFilePath: "metrics.jsonl",
+				LogsFilePath:    "logs.jsonl",
+			},
+			hasError: false,
+		},
+		{
+			name: "malformed collector headers",
+			config: Config{
+				CollectorGRPCURL: "localhost:4317",
+				CollectorHTTPURL: "localhost:4318",
+				ServiceName:      "ogen",
+				PprofURL:         "localhost:1777",
+				CollectorHeaders: "api-key",
+			},
+			hasError: true,
+		},
+		{
+			name: "client cert without key",
+			config: Config{
+				CollectorGRPCURL:        "localhost:4317",
+				CollectorHTTPURL:        "localhost:4318",
+				ServiceName:             "ogen",
+				PprofURL:                "localhost:1777",
+				CollectorClientCertFile: "client.crt",
+			},
+			hasError: true,
+		},
+		{
+			name: "valid mTLS configuration",
+			config: Config{
+				CollectorGRPCURL:        "localhost:4317",
+				CollectorHTTPURL:        "localhost:4318",
+				ServiceName:             "ogen",
+				PprofURL:                "localhost:1777",
+				CollectorInsecure:       false,
+				CollectorCAFile:         "ca.crt",
+				CollectorClientCertFile: "client.crt",
+				CollectorClientKeyFile:  "client.key",
+				CollectorHeaders:        "x-api-key=secret,x-tenant=demo",
+				CollectorCompression:    "gzip",
+			},
+			hasError: false,
+		},
 	}
 
 	for _, tt := range tests {
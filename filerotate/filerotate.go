@@ -0,0 +1,86 @@
+// Package filerotate provides a size-rotated io.Writer for the "file"
+// exporter destination: before a write would take the current file past a
+// threshold, it is renamed aside and a fresh file is opened in its place.
+package filerotate
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Writer is an io.Writer that rotates path before a write would take its
+// contents past maxBytes. A single prior generation is kept, at path+".1";
+// older generations are overwritten.
+type Writer struct {
+	path     string
+	maxBytes int64
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// New opens path for appending, creating it if needed, and returns a Writer
+// that rotates it before a write would take it past maxBytes. A maxBytes of
+// zero disables rotation.
+func New(path string, maxBytes int64) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("opening %q: %w", path, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("statting %q: %w", path, err)
+	}
+
+	return &Writer{path: path, maxBytes: maxBytes, file: f, size: info.Size()}, nil
+}
+
+// Write appends p to the current file, rotating first if doing so would
+// exceed maxBytes.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.maxBytes > 0 && w.size+int64(len(p)) > w.maxBytes {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// rotate renames the current file aside, overwriting any previous
+// generation, and opens a fresh file at path.
+func (w *Writer) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("closing %q for rotation: %w", w.path, err)
+	}
+
+	if err := os.Rename(w.path, w.path+".1"); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotating %q: %w", w.path, err)
+	}
+
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("reopening %q after rotation: %w", w.path, err)
+	}
+
+	w.file = f
+	w.size = 0
+	return nil
+}
+
+// Close closes the underlying file.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.file.Close()
+}
@@ -0,0 +1,48 @@
+package filerotate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/peterldowns/testy/assert"
+)
+
+func TestWriteWithoutRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+
+	w, err := New(path, 0)
+	assert.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("line one\n"))
+	assert.NoError(t, err)
+	_, err = w.Write([]byte("line two\n"))
+	assert.NoError(t, err)
+
+	contents, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "line one\nline two\n", string(contents))
+}
+
+func TestWriteRotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.jsonl")
+
+	w, err := New(path, 10)
+	assert.NoError(t, err)
+	defer w.Close()
+
+	_, err = w.Write([]byte("0123456789"))
+	assert.NoError(t, err)
+
+	_, err = w.Write([]byte("second"))
+	assert.NoError(t, err)
+
+	rotated, err := os.ReadFile(path + ".1")
+	assert.NoError(t, err)
+	assert.Equal(t, "0123456789", string(rotated))
+
+	current, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(current))
+}
@@ -2,26 +2,34 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"log"
 	"log/slog"
-	"math/rand"
 	"net/http"
 	"net/http/pprof"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/log/global"
-	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 
 	olg "go.opentelemetry.io/otel/sdk/log"
@@ -30,29 +38,250 @@ import (
 
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
+
+	"google.golang.org/grpc/credentials"
+
+	"github.com/dnldd/ogen/filerotate"
+	"github.com/dnldd/ogen/retry"
+	"github.com/dnldd/ogen/shutdown"
+	"github.com/dnldd/ogen/tailsample"
+	"github.com/dnldd/ogen/workload"
 )
 
+// shutdownTimeout bounds how long main's teardown waits for the trace,
+// metric and log providers to flush before giving up.
+const shutdownTimeout = 10 * time.Second
+
 var (
 	serviceName = "ogen"
 )
 
+// buildTLSConfig loads the collector mTLS material referenced by cfg. It
+// returns nil when cfg.CollectorInsecure is true; the OTLP exporters below
+// then dial without TLS and do not use the returned config.
+func buildTLSConfig(cfg *Config) (*tls.Config, error) {
+	if cfg.CollectorInsecure {
+		return nil, nil
+	}
+
+	tlsCfg := &tls.Config{}
+
+	if cfg.CollectorCAFile != "" {
+		ca, err := os.ReadFile(cfg.CollectorCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading collector CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("parsing collector CA file %q", cfg.CollectorCAFile)
+		}
+
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.CollectorClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CollectorClientCertFile, cfg.CollectorClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading collector client certificate: %w", err)
+		}
+
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
+
+// collectorHeaders parses cfg.CollectorHeaders ("key=value,key=value") into
+// the map every OTLP exporter's WithHeaders option expects, returning nil
+// when unset.
+func collectorHeaders(cfg *Config) map[string]string {
+	if cfg.CollectorHeaders == "" {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(cfg.CollectorHeaders, ",") {
+		key, value, _ := strings.Cut(pair, "=")
+		headers[key] = value
+	}
+
+	return headers
+}
+
+// newTraceExporter builds the trace exporter for cfg.Exporter. "otlp" (the
+// default) then picks its transport from cfg.TracesProtocol, defaulting to
+// grpc to preserve historical behaviour.
+func newTraceExporter(ctx context.Context, cfg *Config) (trc.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New()
+	case ExporterFile:
+		w, err := filerotate.New(cfg.TracesFilePath, int64(cfg.ExporterFileMaxBytes))
+		if err != nil {
+			return nil, fmt.Errorf("opening traces file exporter: %w", err)
+		}
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		tlsCfg, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		headers := collectorHeaders(cfg)
+
+		switch cfg.TracesProtocol {
+		case ProtocolHTTPProtobuf:
+			retryCfg := retry.DefaultConfig()
+			opts := []otlptracehttp.Option{
+				otlptracehttp.WithEndpoint(cfg.CollectorHTTPURL),
+				otlptracehttp.WithHeaders(headers),
+				otlptracehttp.WithRetry(otlptracehttp.RetryConfig{
+					Enabled:         true,
+					InitialInterval: retryCfg.InitialInterval,
+					MaxInterval:     retryCfg.MaxInterval,
+					MaxElapsedTime:  retryCfg.MaxElapsedTime,
+				}),
+			}
+			if cfg.CollectorInsecure {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			} else {
+				opts = append(opts, otlptracehttp.WithTLSClientConfig(tlsCfg))
+			}
+			if cfg.CollectorCompression == CompressionGzip {
+				opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+			}
+			return otlptracehttp.New(ctx, opts...)
+		default:
+			opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.CollectorGRPCURL), otlptracegrpc.WithHeaders(headers)}
+			if cfg.CollectorInsecure {
+				opts = append(opts, otlptracegrpc.WithInsecure())
+			} else {
+				opts = append(opts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+			}
+			if cfg.CollectorCompression == CompressionGzip {
+				opts = append(opts, otlptracegrpc.WithCompressor("gzip"))
+			}
+			client := otlptracegrpc.NewClient(opts...)
+			return otlptrace.New(ctx, client)
+		}
+	}
+}
+
+// buildSampler translates cfg.Sampler into a trace sampler. Supported
+// values are "always" (default), "never", and "ratio:<fraction>" for a
+// ParentBased TraceIDRatio sampler.
+func buildSampler(value string) (trc.Sampler, error) {
+	switch {
+	case value == "" || value == "always":
+		return trc.AlwaysSample(), nil
+	case value == "never":
+		return trc.NeverSample(), nil
+	case strings.HasPrefix(value, "ratio:"):
+		ratio, err := strconv.ParseFloat(strings.TrimPrefix(value, "ratio:"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing sampler ratio: %w", err)
+		}
+		return trc.ParentBased(trc.TraceIDRatioBased(ratio)), nil
+	default:
+		return nil, fmt.Errorf("unsupported sampler %q", value)
+	}
+}
+
 func setupTracing(ctx context.Context, cfg *Config, res *resource.Resource) func(context.Context) error {
-	client := otlptracegrpc.NewClient(otlptracegrpc.WithInsecure(), otlptracegrpc.WithEndpoint(cfg.CollectorGRPCURL))
-	exporter, err := otlptrace.New(ctx, client)
+	exporter, err := newTraceExporter(ctx, cfg)
 	if err != nil {
 		log.Fatalf("Creating otel trace exporter: %v", err)
 	}
 
-	traceProvider := trc.NewTracerProvider(trc.WithSampler(trc.AlwaysSample()),
-		trc.WithBatcher(exporter), trc.WithResource(res))
+	sampler, err := buildSampler(cfg.Sampler)
+	if err != nil {
+		log.Fatalf("Building trace sampler: %v", err)
+	}
+
+	var processor trc.SpanProcessor = trc.NewBatchSpanProcessor(exporter)
+	if cfg.TailSampleWindowMS > 0 {
+		processor = tailsample.NewProcessor(processor,
+			time.Duration(cfg.TailSampleWindowMS)*time.Millisecond, time.Duration(cfg.TailSampleLatencyThresholdMS)*time.Millisecond)
+	}
+
+	traceProvider := trc.NewTracerProvider(trc.WithSampler(sampler),
+		trc.WithSpanProcessor(processor), trc.WithResource(res))
 
 	otel.SetTracerProvider(traceProvider)
 
 	return traceProvider.Shutdown
 }
 
+// newMetricExporter builds the metric exporter for cfg.Exporter. "otlp" (the
+// default) then picks its transport from cfg.MetricsProtocol, defaulting to
+// http/protobuf to preserve historical behaviour.
+func newMetricExporter(ctx context.Context, cfg *Config) (mtc.Exporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdoutmetric.New()
+	case ExporterFile:
+		w, err := filerotate.New(cfg.MetricsFilePath, int64(cfg.ExporterFileMaxBytes))
+		if err != nil {
+			return nil, fmt.Errorf("opening metrics file exporter: %w", err)
+		}
+		return stdoutmetric.New(stdoutmetric.WithWriter(w))
+	default:
+		tlsCfg, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		headers := collectorHeaders(cfg)
+
+		switch cfg.MetricsProtocol {
+		case ProtocolGRPC:
+			opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(cfg.CollectorGRPCURL), otlpmetricgrpc.WithHeaders(headers)}
+			if cfg.CollectorInsecure {
+				opts = append(opts, otlpmetricgrpc.WithInsecure())
+			} else {
+				opts = append(opts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+			}
+			if cfg.CollectorCompression == CompressionGzip {
+				opts = append(opts, otlpmetricgrpc.WithCompressor("gzip"))
+			}
+			return otlpmetricgrpc.New(ctx, opts...)
+		default:
+			retryCfg := retry.DefaultConfig()
+			opts := []otlpmetrichttp.Option{
+				otlpmetrichttp.WithEndpoint(cfg.CollectorHTTPURL),
+				otlpmetrichttp.WithHeaders(headers),
+				otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig{
+					Enabled:         true,
+					InitialInterval: retryCfg.InitialInterval,
+					MaxInterval:     retryCfg.MaxInterval,
+					MaxElapsedTime:  retryCfg.MaxElapsedTime,
+				}),
+			}
+			if cfg.CollectorInsecure {
+				opts = append(opts, otlpmetrichttp.WithInsecure())
+			} else {
+				opts = append(opts, otlpmetrichttp.WithTLSClientConfig(tlsCfg))
+			}
+			if cfg.CollectorCompression == CompressionNone {
+				opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.NoCompression))
+			} else {
+				// Preserves ogen's historical default of gzip-compressing the
+				// metrics HTTP exporter.
+				opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+			}
+			return otlpmetrichttp.New(ctx, opts...)
+		}
+	}
+}
+
 func setupMetrics(ctx context.Context, cfg *Config, res *resource.Resource) func(context.Context) error {
-	exporter, err := otlpmetrichttp.New(ctx, otlpmetrichttp.WithInsecure(), otlpmetrichttp.WithEndpoint(cfg.CollectorHTTPURL), otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	// Exemplars are gated behind an experimental feature flag in the Go SDK;
+	// turn it on unless the operator has already set it explicitly, so
+	// recorded metric points carry the trace/span ID they were measured in.
+	if _, ok := os.LookupEnv("OTEL_GO_X_EXEMPLAR"); !ok {
+		os.Setenv("OTEL_GO_X_EXEMPLAR", "true")
+	}
+
+	exporter, err := newMetricExporter(ctx, cfg)
 	if err != nil {
 		log.Fatalf("Creating otel metrics exporter: %v", err)
 	}
@@ -69,8 +298,69 @@ func setupMetrics(ctx context.Context, cfg *Config, res *resource.Resource) func
 	return meterProvider.Shutdown
 }
 
+// newLogExporter builds the log exporter for cfg.Exporter. "otlp" (the
+// default) then picks its transport from cfg.LogsProtocol, defaulting to
+// http/protobuf to preserve historical behaviour.
+func newLogExporter(ctx context.Context, cfg *Config) (olg.Exporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdoutlog.New()
+	case ExporterFile:
+		w, err := filerotate.New(cfg.LogsFilePath, int64(cfg.ExporterFileMaxBytes))
+		if err != nil {
+			return nil, fmt.Errorf("opening logs file exporter: %w", err)
+		}
+		return stdoutlog.New(stdoutlog.WithWriter(w))
+	default:
+		tlsCfg, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		headers := collectorHeaders(cfg)
+
+		switch cfg.LogsProtocol {
+		case ProtocolGRPC:
+			opts := []otlploggrpc.Option{otlploggrpc.WithEndpoint(cfg.CollectorGRPCURL), otlploggrpc.WithHeaders(headers)}
+			if cfg.CollectorInsecure {
+				opts = append(opts, otlploggrpc.WithInsecure())
+			} else {
+				opts = append(opts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsCfg)))
+			}
+			if cfg.CollectorCompression == CompressionGzip {
+				opts = append(opts, otlploggrpc.WithCompressor("gzip"))
+			}
+			return otlploggrpc.New(ctx, opts...)
+		default:
+			retryCfg := retry.DefaultConfig()
+			opts := []otlploghttp.Option{
+				otlploghttp.WithEndpoint(cfg.CollectorHTTPURL),
+				otlploghttp.WithHeaders(headers),
+				otlploghttp.WithRetry(otlploghttp.RetryConfig{
+					Enabled:         true,
+					InitialInterval: retryCfg.InitialInterval,
+					MaxInterval:     retryCfg.MaxInterval,
+					MaxElapsedTime:  retryCfg.MaxElapsedTime,
+				}),
+			}
+			if cfg.CollectorInsecure {
+				opts = append(opts, otlploghttp.WithInsecure())
+			} else {
+				opts = append(opts, otlploghttp.WithTLSClientConfig(tlsCfg))
+			}
+			if cfg.CollectorCompression == CompressionNone {
+				opts = append(opts, otlploghttp.WithCompression(otlploghttp.NoCompression))
+			} else {
+				// Preserves ogen's historical default of gzip-compressing the
+				// logs HTTP exporter.
+				opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+			}
+			return otlploghttp.New(ctx, opts...)
+		}
+	}
+}
+
 func setupLogging(ctx context.Context, cfg *Config, resource *resource.Resource) func(context.Context) error {
-	logExporter, err := otlploghttp.New(ctx, otlploghttp.WithInsecure(), otlploghttp.WithEndpoint(cfg.CollectorHTTPURL), otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	logExporter, err := newLogExporter(ctx, cfg)
 	if err != nil {
 		log.Fatalf("Creating log exporter: %v", err)
 	}
@@ -81,69 +371,52 @@ func setupLogging(ctx context.Context, cfg *Config, resource *resource.Resource)
 	return logProvider.Shutdown
 }
 
-// rollDice generates metrics, logs and traces based on the provided roll.
-func rollDice(ctx context.Context, roll int64, cfg *Config, logger *slog.Logger) {
-	tracer := otel.Tracer(cfg.ServiceName)
-	_, span := tracer.Start(ctx, "dice_roll")
-	defer span.End()
+// defaultWorkloads is used when the operator doesn't set `workloads`,
+// preserving ogen's original dice-roll-only behaviour.
+const defaultWorkloads = "dice"
 
-	span.SetAttributes(attribute.String("action", "roll"))
-
-	span.SetAttributes(attribute.Int64("roll", roll))
-	fmt.Print(roll)
-
-	switch {
-	case roll == 0:
-		logger.LogAttrs(ctx, slog.LevelInfo, "Rolled zero", slog.Int64("roll", roll))
-		time.Sleep(time.Second)
-	case roll%2 == 0:
-		logger.LogAttrs(ctx, slog.LevelInfo, "Rolled even", slog.Int64("roll", roll))
-		time.Sleep(time.Second * time.Duration(roll/2))
-	case roll == 1:
-		logger.LogAttrs(ctx, slog.LevelInfo, "Rolled one", slog.Int64("roll", roll))
-		time.Sleep(time.Second)
-	case roll%2 != 0:
-		logger.LogAttrs(ctx, slog.LevelInfo, "Rolled odd", slog.Int64("roll", roll))
-		time.Sleep(time.Second * time.Duration(roll/2))
-	}
-}
-
-// generateData uses a pseudo-random dice roll to generate observability metrics and traces.
+// generateData starts cfg.WorkloadConcurrency workers (default 1) per
+// configured workload and blocks until
+// ctx is cancelled, driving whichever mix of scenarios the operator picked
+// via `workloads`.
 func generateData(ctx context.Context, cfg *Config, logger *slog.Logger, wg *sync.WaitGroup) {
 	defer wg.Done()
 
-	meter := otel.Meter(cfg.ServiceName)
-
-	diceRolls, err := meter.Int64Counter("dice-rolls", metric.WithDescription("Counts the total number of dice rolls"))
-	if err != nil {
-		logger.LogAttrs(ctx, slog.LevelError, "Creating counter", slog.String("name", "dice-rolls"))
-		return
+	names := cfg.Workloads
+	if names == "" {
+		names = defaultWorkloads
 	}
 
-	rollFreq := make(map[int64]metric.Int64Counter)
-	for idx := int64(0); idx < 11; idx++ {
-		counter, err := meter.Int64Counter(fmt.Sprintf("roll-%d-count", idx),
-			metric.WithDescription(fmt.Sprintf("Counter the total number of dice rolls for the number %d", idx)))
-		if err != nil {
-			logger.LogAttrs(ctx, slog.LevelError, "Creating roll counter", slog.String("name", "roll"), slog.Int64("number", idx))
-			return
-		}
-
-		rollFreq[idx] = counter
+	concurrency := cfg.WorkloadConcurrency
+	if concurrency == 0 {
+		concurrency = 1
 	}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			roll := int64(rand.Intn(10))
-			rollDice(ctx, roll, cfg, logger)
+	opts := workload.Options{ServiceName: cfg.ServiceName, Rate: cfg.WorkloadRate}
 
-			diceRolls.Add(ctx, 1)
-			rollFreq[roll].Add(ctx, 1)
+	var workers sync.WaitGroup
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		for i := uint64(0); i < concurrency; i++ {
+			w, ok := workload.Get(name)
+			if !ok {
+				logger.ErrorContext(ctx, "Unknown workload", slog.String("name", name))
+				continue
+			}
+
+			workers.Add(1)
+			go func(w workload.Workload) {
+				defer workers.Done()
+				w.Run(ctx, opts, logger)
+			}(w)
 		}
 	}
+
+	workers.Wait()
 }
 
 func setupPprof(cfg *Config) *http.Server {
@@ -170,7 +443,10 @@ func servePprof(ctx context.Context, server *http.Server, logger *slog.Logger, w
 }
 
 // handleTermination processes context cancellation signals or interrupt signals from the OS.
-func handleTermination(ctx context.Context, cancel context.CancelFunc, teardown func(context.Context), wg *sync.WaitGroup) {
+// teardown is run against a fresh context once the generator is told to stop, so a flush isn't
+// racing the same cancellation that triggered it; exitOnFailure lets tests observe a failed
+// teardown without killing the test binary.
+func handleTermination(ctx context.Context, cancel context.CancelFunc, teardown func(context.Context) bool, exitOnFailure bool, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	// Listen for interrupt signals.
@@ -182,7 +458,9 @@ func handleTermination(ctx context.Context, cancel context.CancelFunc, teardown
 	for {
 		select {
 		case <-ctx.Done():
-			teardown(ctx)
+			if ok := teardown(context.Background()); !ok && exitOnFailure {
+				os.Exit(1)
+			}
 			return
 
 		case <-interrupt:
@@ -227,16 +505,20 @@ func main() {
 
 	ppf := setupPprof(cfg)
 
-	teardown := func(ctx context.Context) {
+	teardown := func(ctx context.Context) bool {
 		_ = ppf.Close()
-		logCleanup(ctx)
-		traceCleanup(ctx)
-		meterCleanup(ctx)
+
+		coordinator := shutdown.New()
+		coordinator.Add("traces", traceCleanup)
+		coordinator.Add("logs", logCleanup)
+		coordinator.Add("metrics", meterCleanup)
+
+		return coordinator.Run(ctx, shutdownTimeout, logger)
 	}
 
 	wg.Add(3)
 	go servePprof(ctx, ppf, logger, &wg)
-	go handleTermination(ctx, cancel, teardown, &wg)
+	go handleTermination(ctx, cancel, teardown, true, &wg)
 	go generateData(ctx, cfg, logger, &wg)
 	wg.Wait()
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dnldd/ogen/shutdown"
 	"github.com/peterldowns/testy/assert"
 	"go.opentelemetry.io/contrib/bridges/otelslog"
 	"go.opentelemetry.io/otel/attribute"
@@ -15,10 +16,9 @@ import (
 
 func TestGracefulShutdown(t *testing.T) {
 	cfg := &Config{
-		CollectorGRPCURL: "localhost:4317",
-		CollectorHTTPURL: "localhost:4318",
-		ServiceName:      "ogen",
-		PprofURL:         "localhost:1777",
+		ServiceName: "ogen",
+		PprofURL:    "localhost:1777",
+		Exporter:    "stdout",
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -43,23 +43,29 @@ func TestGracefulShutdown(t *testing.T) {
 
 	ppf := setupPprof(cfg)
 
-	teardown := func(ctx context.Context) {
+	logger := otelslog.NewLogger(cfg.ServiceName)
+
+	teardown := func(ctx context.Context) bool {
 		_ = ppf.Close()
-		traceCleanup(ctx)
-		meterCleanup(ctx)
-		logCleanup(ctx)
-	}
 
-	logger := otelslog.NewLogger(cfg.ServiceName)
+		coordinator := shutdown.New()
+		coordinator.Add("traces", traceCleanup)
+		coordinator.Add("logs", logCleanup)
+		coordinator.Add("metrics", meterCleanup)
 
-	// Ensure the generator can be started and terminated gracefully.
-	time.AfterFunc(time.Second*5, func() {
-		cancel()
-		teardown(ctx)
-	})
+		return coordinator.Run(ctx, 5*time.Second, logger)
+	}
 
 	wg.Add(2)
 	go servePprof(ctx, ppf, logger, &wg)
 	go generateData(ctx, cfg, logger, &wg)
+
+	// Let the generator run briefly, then tear everything down
+	// synchronously: driving cancel/teardown from a timer goroutine raced
+	// the test returning out from under it.
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	assert.True(t, teardown(context.Background()))
+
 	wg.Wait()
 }
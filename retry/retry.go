@@ -0,0 +1,26 @@
+// Package retry holds the backoff schedule shared by ogen's OTLP HTTP
+// exporters. The exporters don't expose a pluggable http.Client (there is
+// no WithHTTPClient option), so this schedule is fed into each exporter's
+// own built-in retry option rather than wrapping a transport directly.
+package retry
+
+import "time"
+
+// Config controls the backoff schedule. InitialInterval is the first retry
+// delay, MaxInterval caps how large it's allowed to grow, and
+// MaxElapsedTime bounds the total time spent retrying a single request.
+type Config struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// DefaultConfig returns the backoff schedule used when none is supplied:
+// a half-second initial delay growing up to 30s, giving up after a minute.
+func DefaultConfig() Config {
+	return Config{
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     30 * time.Second,
+		MaxElapsedTime:  time.Minute,
+	}
+}
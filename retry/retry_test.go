@@ -0,0 +1,16 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/peterldowns/testy/assert"
+)
+
+func TestDefaultConfig(t *testing.T) {
+	cfg := DefaultConfig()
+
+	assert.Equal(t, 500*time.Millisecond, cfg.InitialInterval)
+	assert.Equal(t, 30*time.Second, cfg.MaxInterval)
+	assert.Equal(t, time.Minute, cfg.MaxElapsedTime)
+}
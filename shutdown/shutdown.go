@@ -0,0 +1,81 @@
+// Package shutdown provides a bounded-timeout coordinator for tearing down
+// the OTel providers in a fixed order, instead of racing an ad-hoc timer
+// against however long each flush happens to take.
+package shutdown
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Flush is a teardown step, e.g. a TracerProvider's Shutdown method.
+type Flush func(context.Context) error
+
+type step struct {
+	name  string
+	flush Flush
+}
+
+// Coordinator runs a sequence of Flushes in order within an overall
+// deadline, so a slow or unreachable collector can't hang shutdown
+// indefinitely.
+type Coordinator struct {
+	steps []step
+}
+
+// New returns an empty Coordinator.
+func New() *Coordinator {
+	return &Coordinator{}
+}
+
+// Add appends a named flush step, run in the order Add was called.
+func (c *Coordinator) Add(name string, flush Flush) {
+	c.steps = append(c.steps, step{name: name, flush: flush})
+}
+
+// Run executes every registered step in order, each against a fresh
+// sub-context derived from a single overall timeout. If the timeout elapses
+// before a step runs, that step (and any remaining) is skipped rather than
+// attempted. Run logs the outcome and reports whether every step completed
+// without error or being skipped; callers should translate a false return
+// into a non-zero process exit code.
+//
+// The logged count is of skipped steps, not dropped telemetry items: the
+// SDK's TracerProvider/MeterProvider/LoggerProvider Shutdown and ForceFlush
+// methods return only success/failure, with no API to ask a batch
+// processor or periodic reader how many buffered spans, metrics or log
+// records it was still holding. A true dropped-item count isn't available
+// without that upstream support, so "steps skipped" is the closest proxy
+// this package can report.
+func (c *Coordinator) Run(ctx context.Context, timeout time.Duration, logger *slog.Logger) bool {
+	deadline := time.Now().Add(timeout)
+
+	ok := true
+	skipped := 0
+
+	for _, s := range c.steps {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			skipped++
+			ok = false
+			continue
+		}
+
+		stepCtx, cancel := context.WithTimeout(ctx, remaining)
+		err := s.flush(stepCtx)
+		cancel()
+
+		if err != nil {
+			logger.ErrorContext(ctx, "Shutdown step failed", slog.String("step", s.name), slog.String("err", err.Error()))
+			ok = false
+		}
+	}
+
+	if skipped > 0 {
+		logger.ErrorContext(ctx, "Shutdown deadline exceeded before all steps ran",
+			slog.Int("skipped", skipped), slog.Int("total", len(c.steps)))
+	}
+
+	return ok
+}
@@ -0,0 +1,56 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/peterldowns/testy/assert"
+)
+
+func TestRunAllStepsSucceed(t *testing.T) {
+	c := New()
+
+	var ran []string
+	c.Add("traces", func(context.Context) error {
+		ran = append(ran, "traces")
+		return nil
+	})
+	c.Add("logs", func(context.Context) error {
+		ran = append(ran, "logs")
+		return nil
+	})
+
+	ok := c.Run(context.Background(), time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.True(t, ok)
+	assert.Equal(t, []string{"traces", "logs"}, ran)
+}
+
+func TestRunReportsFailedStep(t *testing.T) {
+	c := New()
+	c.Add("metrics", func(context.Context) error { return errors.New("flush failed") })
+
+	ok := c.Run(context.Background(), time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.False(t, ok)
+}
+
+func TestRunDropsStepsPastDeadline(t *testing.T) {
+	c := New()
+
+	ran := false
+	c.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	c.Add("never-runs", func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	ok := c.Run(context.Background(), 10*time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.False(t, ok)
+	assert.False(t, ran)
+}
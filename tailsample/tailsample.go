@@ -0,0 +1,132 @@
+// Package tailsample implements a lightweight local tail-sampling span
+// processor. It buffers each trace's finished spans for a fixed window and
+// only forwards the trace to the next processor if it contains an error
+// span or ran for at least a latency threshold; every other trace is
+// dropped once its window elapses.
+package tailsample
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Processor buffers finished spans per trace ID and, once the configured
+// window elapses for a trace, either forwards every buffered span to next
+// or drops them.
+type Processor struct {
+	next             sdktrace.SpanProcessor
+	window           time.Duration
+	latencyThreshold time.Duration
+
+	mu     sync.Mutex
+	traces map[trace.TraceID]*bufferedTrace
+}
+
+type bufferedTrace struct {
+	spans         []sdktrace.ReadOnlySpan
+	earliestStart time.Time
+	latestEnd     time.Time
+	hasError      bool
+}
+
+// NewProcessor returns a Processor that buffers each trace for window
+// before deciding whether to forward it to next, keeping traces that
+// contain an error span or whose total duration is at least
+// latencyThreshold.
+func NewProcessor(next sdktrace.SpanProcessor, window, latencyThreshold time.Duration) *Processor {
+	return &Processor{
+		next:             next,
+		window:           window,
+		latencyThreshold: latencyThreshold,
+		traces:           make(map[trace.TraceID]*bufferedTrace),
+	}
+}
+
+// OnStart is a no-op: the sampling decision is made from completed spans
+// only, once a trace's window elapses.
+func (p *Processor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {}
+
+// OnEnd buffers s under its trace ID, scheduling a decision after window if
+// this is the first span seen for that trace.
+func (p *Processor) OnEnd(s sdktrace.ReadOnlySpan) {
+	traceID := s.SpanContext().TraceID()
+
+	p.mu.Lock()
+	bt, ok := p.traces[traceID]
+	if !ok {
+		bt = &bufferedTrace{}
+		p.traces[traceID] = bt
+		time.AfterFunc(p.window, func() { p.decide(traceID) })
+	}
+
+	bt.spans = append(bt.spans, s)
+	if bt.earliestStart.IsZero() || s.StartTime().Before(bt.earliestStart) {
+		bt.earliestStart = s.StartTime()
+	}
+	if s.EndTime().After(bt.latestEnd) {
+		bt.latestEnd = s.EndTime()
+	}
+	if s.Status().Code == codes.Error {
+		bt.hasError = true
+	}
+	p.mu.Unlock()
+}
+
+// decide forwards every buffered span for traceID to next if the trace
+// qualifies for sampling, then discards the buffer either way.
+func (p *Processor) decide(traceID trace.TraceID) {
+	p.mu.Lock()
+	bt, ok := p.traces[traceID]
+	delete(p.traces, traceID)
+	p.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	p.forward(bt)
+}
+
+// forward sends bt's spans to next if it contains an error span or its
+// observed duration is at least the latency threshold.
+func (p *Processor) forward(bt *bufferedTrace) {
+	if !bt.hasError && bt.latestEnd.Sub(bt.earliestStart) < p.latencyThreshold {
+		return
+	}
+
+	for _, s := range bt.spans {
+		p.next.OnEnd(s)
+	}
+}
+
+// Shutdown forwards every currently buffered trace without waiting out its
+// window, then shuts down next.
+func (p *Processor) Shutdown(ctx context.Context) error {
+	p.flushAll()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush forwards every currently buffered trace without waiting out
+// its window, then force-flushes next.
+func (p *Processor) ForceFlush(ctx context.Context) error {
+	p.flushAll()
+	return p.next.ForceFlush(ctx)
+}
+
+// flushAll forwards every currently buffered trace so in-flight traces
+// aren't silently dropped on shutdown/force-flush.
+func (p *Processor) flushAll() {
+	p.mu.Lock()
+	traces := p.traces
+	p.traces = make(map[trace.TraceID]*bufferedTrace)
+	p.mu.Unlock()
+
+	for _, bt := range traces {
+		p.forward(bt)
+	}
+}
@@ -0,0 +1,90 @@
+package tailsample
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/peterldowns/testy/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type recordingProcessor struct {
+	ended []sdktrace.ReadOnlySpan
+}
+
+func (r *recordingProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {}
+func (r *recordingProcessor) OnEnd(s sdktrace.ReadOnlySpan)                         { r.ended = append(r.ended, s) }
+func (r *recordingProcessor) Shutdown(ctx context.Context) error                    { return nil }
+func (r *recordingProcessor) ForceFlush(ctx context.Context) error                  { return nil }
+
+func TestProcessorDropsQuietTraces(t *testing.T) {
+	rec := &recordingProcessor{}
+	proc := NewProcessor(rec, 20*time.Millisecond, time.Hour)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(proc))
+	tracer := tp.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "quiet")
+	span.End()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, 0, len(rec.ended))
+}
+
+func TestProcessorKeepsErrorTraces(t *testing.T) {
+	rec := &recordingProcessor{}
+	proc := NewProcessor(rec, 20*time.Millisecond, time.Hour)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(proc))
+	tracer := tp.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "erroring")
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, 1, len(rec.ended))
+}
+
+func TestProcessorTracksEarliestSpanStart(t *testing.T) {
+	rec := &recordingProcessor{}
+	proc := NewProcessor(rec, 30*time.Millisecond, 15*time.Millisecond)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(proc))
+	tracer := tp.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	time.Sleep(20 * time.Millisecond)
+
+	// The child starts after the parent but ends (and is buffered) first,
+	// so its later start time must not win out over the parent's earlier one.
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+
+	time.Sleep(5 * time.Millisecond)
+	parent.End()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, 2, len(rec.ended))
+}
+
+func TestProcessorKeepsSlowTraces(t *testing.T) {
+	rec := &recordingProcessor{}
+	proc := NewProcessor(rec, 20*time.Millisecond, time.Millisecond)
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(proc))
+	tracer := tp.Tracer("test")
+
+	_, span := tracer.Start(context.Background(), "slow")
+	time.Sleep(5 * time.Millisecond)
+	span.End()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.Equal(t, 1, len(rec.ended))
+}
@@ -0,0 +1,102 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func init() {
+	Register("dice", func() Workload { return &diceWorkload{} })
+}
+
+// diceWorkload is the original ogen scenario: roll a ten-sided die in a
+// loop, recording a span, a log line and a couple of counters per roll.
+type diceWorkload struct{}
+
+func (w *diceWorkload) Name() string { return "dice" }
+
+func (w *diceWorkload) Run(ctx context.Context, opts Options, logger *slog.Logger) {
+	meter := otel.Meter(opts.ServiceName)
+
+	diceRolls, err := meter.Int64Counter("dice-rolls", metric.WithDescription("Counts the total number of dice rolls"))
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating counter", slog.String("name", "dice-rolls"))
+		return
+	}
+
+	rollFreq := make(map[int64]metric.Int64Counter)
+	for idx := int64(0); idx < 11; idx++ {
+		counter, err := meter.Int64Counter(fmt.Sprintf("roll-%d-count", idx),
+			metric.WithDescription(fmt.Sprintf("Counter the total number of dice rolls for the number %d", idx)))
+		if err != nil {
+			logger.ErrorContext(ctx, "Creating roll counter", slog.String("name", "roll"), slog.Int64("number", idx))
+			return
+		}
+
+		rollFreq[idx] = counter
+	}
+
+	rollLatency, err := meter.Float64Histogram("roll_latency",
+		metric.WithDescription("Records the latency of a dice roll"), metric.WithUnit("s"))
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating histogram", slog.String("name", "roll_latency"))
+		return
+	}
+
+	limiter := newLimiter(opts.Rate)
+	defer limiter.stop()
+
+	for {
+		if !limiter.wait(ctx) {
+			return
+		}
+
+		roll := int64(rand.Intn(10))
+		spanCtx, latency := rollDice(ctx, roll, opts.ServiceName, logger)
+
+		// Recording through spanCtx, rather than the loop's ctx, lets the
+		// SDK attach the roll's trace/span ID to these points as exemplars.
+		diceRolls.Add(spanCtx, 1)
+		rollFreq[roll].Add(spanCtx, 1)
+		rollLatency.Record(spanCtx, latency.Seconds())
+	}
+}
+
+// rollDice generates a span and a log line for roll, sleeping for the
+// duration the roll implies, and returns the span's context plus how long
+// it slept so the caller can record exemplar-linked metrics.
+func rollDice(ctx context.Context, roll int64, serviceName string, logger *slog.Logger) (context.Context, time.Duration) {
+	tracer := otel.Tracer(serviceName)
+	spanCtx, span := tracer.Start(ctx, "dice_roll")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("action", "roll"))
+	span.SetAttributes(attribute.Int64("roll", roll))
+	fmt.Print(roll)
+
+	var sleep time.Duration
+	switch {
+	case roll == 0:
+		logger.LogAttrs(spanCtx, slog.LevelInfo, "Rolled zero", slog.Int64("roll", roll))
+		sleep = time.Second
+	case roll%2 == 0:
+		logger.LogAttrs(spanCtx, slog.LevelInfo, "Rolled even", slog.Int64("roll", roll))
+		sleep = time.Second * time.Duration(roll/2)
+	case roll == 1:
+		logger.LogAttrs(spanCtx, slog.LevelInfo, "Rolled one", slog.Int64("roll", roll))
+		sleep = time.Second
+	case roll%2 != 0:
+		logger.LogAttrs(spanCtx, slog.LevelInfo, "Rolled odd", slog.Int64("roll", roll))
+		sleep = time.Second * time.Duration(roll/2)
+	}
+	time.Sleep(sleep)
+
+	return spanCtx, sleep
+}
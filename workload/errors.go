@@ -0,0 +1,50 @@
+package workload
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	Register("errors", func() Workload { return &errorsWorkload{} })
+}
+
+// errorsWorkload emits slog.LevelError records alongside spans marked
+// codes.Error with a recorded exception, for exercising error-path
+// processing (alerting rules, exemplar linking, exception grouping) in a
+// collector pipeline.
+type errorsWorkload struct{}
+
+func (w *errorsWorkload) Name() string { return "errors" }
+
+func (w *errorsWorkload) Run(ctx context.Context, opts Options, logger *slog.Logger) {
+	tracer := otel.Tracer(opts.ServiceName)
+
+	limiter := newLimiter(opts.Rate)
+	defer limiter.stop()
+
+	for {
+		if !limiter.wait(ctx) {
+			return
+		}
+
+		w.emit(ctx, tracer, logger)
+	}
+}
+
+func (w *errorsWorkload) emit(ctx context.Context, tracer trace.Tracer, logger *slog.Logger) {
+	spanCtx, span := tracer.Start(ctx, "simulated_failure")
+	defer span.End()
+
+	err := errors.New("simulated downstream failure")
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	logger.LogAttrs(spanCtx, slog.LevelError, "Simulated downstream failure", slog.String("err", err.Error()))
+}
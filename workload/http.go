@@ -0,0 +1,84 @@
+package workload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+func init() {
+	Register("http", func() Workload { return &httpWorkload{} })
+}
+
+// httpWorkload stands up an otelhttp-instrumented server and self-drives it
+// with an instrumented client, producing correlated server+client spans and
+// an http.server.request.duration histogram, modelled on the upstream OTel
+// demo's request flow.
+type httpWorkload struct{}
+
+func (w *httpWorkload) Name() string { return "http" }
+
+func (w *httpWorkload) Run(ctx context.Context, opts Options, logger *slog.Logger) {
+	meter := otel.Meter(opts.ServiceName)
+
+	requestDuration, err := meter.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP server requests"), metric.WithUnit("s"))
+	if err != nil {
+		logger.ErrorContext(ctx, "Creating histogram", slog.String("name", "http.server.request.duration"))
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/roll", func(rw http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		defer func() {
+			requestDuration.Record(r.Context(), time.Since(start).Seconds())
+		}()
+
+		logger.InfoContext(r.Context(), "Handling roll request")
+		fmt.Fprintln(rw, "ok")
+	})
+
+	server := httptest.NewServer(otelhttp.NewHandler(mux, "ogen.http"))
+	defer server.Close()
+
+	client := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+
+	limiter := newLimiter(opts.Rate)
+	defer limiter.stop()
+
+	for {
+		if !limiter.wait(ctx) {
+			return
+		}
+
+		w.call(ctx, client, server.URL, logger)
+	}
+}
+
+func (w *httpWorkload) call(ctx context.Context, client *http.Client, baseURL string, logger *slog.Logger) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/roll", nil)
+	if err != nil {
+		logger.ErrorContext(ctx, "Building request", slog.String("err", err.Error()))
+		return
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		logger.ErrorContext(ctx, "Calling server", slog.String("err", err.Error()))
+		return
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		logger.ErrorContext(ctx, "Reading response", slog.String("err", err.Error()))
+	}
+}
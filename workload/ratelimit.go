@@ -0,0 +1,49 @@
+package workload
+
+import (
+	"context"
+	"time"
+)
+
+// limiter paces a workload's loop to roughly rate operations per second.
+// A zero rate never blocks.
+type limiter struct {
+	ticker *time.Ticker
+}
+
+// newLimiter builds a limiter for rate operations per second. A zero rate
+// returns a limiter whose wait is a no-op.
+func newLimiter(rate uint64) *limiter {
+	if rate == 0 {
+		return &limiter{}
+	}
+
+	return &limiter{ticker: time.NewTicker(time.Second / time.Duration(rate))}
+}
+
+// wait blocks until the next tick is due, or returns false if ctx is
+// cancelled first.
+func (l *limiter) wait(ctx context.Context) bool {
+	if l.ticker == nil {
+		select {
+		case <-ctx.Done():
+			return false
+		default:
+			return true
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-l.ticker.C:
+		return true
+	}
+}
+
+// stop releases the underlying ticker, if any.
+func (l *limiter) stop() {
+	if l.ticker != nil {
+		l.ticker.Stop()
+	}
+}
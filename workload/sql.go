@@ -0,0 +1,85 @@
+package workload
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/XSAM/otelsql"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	_ "modernc.org/sqlite"
+)
+
+func init() {
+	Register("sql", func() Workload { return &sqlWorkload{} })
+}
+
+// sqlWorkload opens an in-memory sqlite database through otelsql, a driver
+// wrapper that emits a client span per query, and repeatedly queries a
+// seeded table to produce realistic DB-call telemetry.
+type sqlWorkload struct{}
+
+func (w *sqlWorkload) Name() string { return "sql" }
+
+func (w *sqlWorkload) Run(ctx context.Context, opts Options, logger *slog.Logger) {
+	db, err := otelsql.Open("sqlite", ":memory:", otelsql.WithAttributes(semconv.DBSystemSqlite))
+	if err != nil {
+		logger.ErrorContext(ctx, "Opening sqlite database", slog.String("err", err.Error()))
+		return
+	}
+	defer db.Close()
+
+	// modernc's sqlite driver gives every pooled connection its own
+	// independent in-memory database, so a second connection (or the seeded
+	// one being reaped) would make the seeded table disappear. Pin the pool
+	// to a single connection to keep them sharing it.
+	db.SetMaxOpenConns(1)
+
+	if err := otelsql.RegisterDBStatsMetrics(db, otelsql.WithAttributes(semconv.DBSystemSqlite)); err != nil {
+		logger.ErrorContext(ctx, "Registering db stats metrics", slog.String("err", err.Error()))
+	}
+
+	if err := w.seed(ctx, db); err != nil {
+		logger.ErrorContext(ctx, "Seeding sqlite database", slog.String("err", err.Error()))
+		return
+	}
+
+	limiter := newLimiter(opts.Rate)
+	defer limiter.stop()
+
+	for {
+		if !limiter.wait(ctx) {
+			return
+		}
+
+		w.query(ctx, db, logger)
+	}
+}
+
+func (w *sqlWorkload) seed(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE rolls (roll INTEGER NOT NULL)`); err != nil {
+		return err
+	}
+
+	_, err := db.ExecContext(ctx, `INSERT INTO rolls (roll) VALUES (1), (2), (3), (4), (5)`)
+	return err
+}
+
+func (w *sqlWorkload) query(ctx context.Context, db *sql.DB, logger *slog.Logger) {
+	rows, err := db.QueryContext(ctx, `SELECT roll FROM rolls ORDER BY RANDOM() LIMIT 1`)
+	if err != nil {
+		logger.ErrorContext(ctx, "Querying rolls", slog.String("err", err.Error()))
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var roll int
+		if err := rows.Scan(&roll); err != nil {
+			logger.ErrorContext(ctx, "Scanning roll", slog.String("err", err.Error()))
+			return
+		}
+
+		logger.InfoContext(ctx, "Queried roll", slog.Int("roll", roll))
+	}
+}
@@ -0,0 +1,57 @@
+// Package workload defines the pluggable generators ogen drives to produce
+// synthetic telemetry. Each workload models a realistic scenario (a dice
+// roll loop, an instrumented HTTP round-trip, a traced DB query, a burst of
+// errors) and is selected at runtime via the `workloads` flag.
+package workload
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// Options carries the configuration every workload needs to emit telemetry
+// under the same resource and to honor the operator's rate knob. The
+// concurrency knob is applied by the caller, which starts that many
+// workloads.
+type Options struct {
+	// ServiceName is used to look up the process-wide tracer, meter and
+	// logger so every workload reports under the same resource.
+	ServiceName string
+	// Rate caps the workload to roughly this many operations per second.
+	// Zero means unthrottled.
+	Rate uint64
+}
+
+// Workload generates telemetry for a single synthetic scenario. Run blocks,
+// producing telemetry until ctx is cancelled.
+type Workload interface {
+	// Name identifies the workload for the `workloads` flag and logging.
+	Name() string
+	// Run drives the workload until ctx is cancelled.
+	Run(ctx context.Context, opts Options, logger *slog.Logger)
+}
+
+var registry = make(map[string]func() Workload)
+
+// Register adds a workload constructor to the registry under name. It
+// panics on duplicate registration, mirroring how database/sql registers
+// drivers.
+func Register(name string, newWorkload func() Workload) {
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("workload: Register called twice for workload %q", name))
+	}
+
+	registry[name] = newWorkload
+}
+
+// Get returns a new instance of the workload registered under name, and
+// whether one was found.
+func Get(name string) (Workload, bool) {
+	newWorkload, ok := registry[name]
+	if !ok {
+		return nil, false
+	}
+
+	return newWorkload(), true
+}
@@ -0,0 +1,20 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/peterldowns/testy/assert"
+)
+
+func TestGetRegistered(t *testing.T) {
+	for _, name := range []string{"dice", "http", "sql", "errors"} {
+		w, ok := Get(name)
+		assert.True(t, ok)
+		assert.Equal(t, name, w.Name())
+	}
+}
+
+func TestGetUnknown(t *testing.T) {
+	_, ok := Get("carrier-pigeon")
+	assert.False(t, ok)
+}